@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// BenchmarkResult is a parsed form of one line of `go test -bench` output, following
+// the same fields as testing.BenchmarkResult.String() produces: N iterations, the
+// required ns/op measurement, and whichever of the optional -benchmem/custom metrics
+// were present on the line.
+type BenchmarkResult struct {
+	Name        string
+	N           int
+	NsPerOp     float64
+	AllocsPerOp int64
+	MemBytes    int64 // B/op
+	Custom      map[string]float64
+}
+
+// isBenchmarkHeaderLine reports whether line is one of the "goos:"/"goarch:"/"pkg:"/
+// "cpu:" lines `go test -bench` prints before the results. These, like the benchmark
+// result lines themselves, must never be buffered away in quiet mode. The trailing
+// "PASS"/"FAIL" lines are not matched here; addLine handles them with the other
+// global markers.
+func isBenchmarkHeaderLine(line string) bool {
+	for _, prefix := range []string{"goos:", "goarch:", "pkg:", "cpu:"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBenchmarkLine parses a single "BenchmarkName-8  1000000  120 ns/op  16 B/op  1
+// allocs/op" line, per the grammar testing.BenchmarkResult.String() emits: the
+// benchmark name (optionally suffixed with "-N" for GOMAXPROCS), the iteration count,
+// the mandatory "ns/op" value, and zero or more additional "<value> <unit>/op" pairs
+// (including custom metrics reported via b.ReportMetric).
+func parseBenchmarkLine(line string) (BenchmarkResult, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return BenchmarkResult{}, false
+	}
+
+	n, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return BenchmarkResult{}, false
+	}
+
+	result := BenchmarkResult{Name: fields[0], N: n}
+
+	rest := fields[2:]
+	for i := 0; i+1 < len(rest); i += 2 {
+		value, err := strconv.ParseFloat(rest[i], 64)
+		if err != nil {
+			continue
+		}
+		switch unit := rest[i+1]; unit {
+		case "ns/op":
+			result.NsPerOp = value
+		case "B/op":
+			result.MemBytes = int64(value)
+		case "allocs/op":
+			result.AllocsPerOp = int64(value)
+		default:
+			if result.Custom == nil {
+				result.Custom = make(map[string]float64)
+			}
+			result.Custom[strings.TrimSuffix(unit, "/op")] = value
+		}
+	}
+
+	return result, true
+}
+
+// encodeBenchmarkJSON renders a BenchmarkResult as a single JSON line for the
+// -benchjson option, so tools that embed wasmbrowsertest can consume benchmark
+// results without re-parsing the textual BenchmarkResult.String() form. This is a
+// bespoke schema for that purpose, not the format benchstat reads: benchstat parses
+// the plain BenchmarkResult.String() lines directly, and has no JSON input mode to
+// match here.
+func encodeBenchmarkJSON(r BenchmarkResult, output func(string)) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	output(string(b))
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // ConsoleFilter buffers console output and filters out passing tests when in quiet mode.
@@ -10,6 +11,16 @@ type ConsoleFilter struct {
 	buffer []string
 	quiet  bool
 	output func(string) // callback to write output
+
+	// json, when set, makes the filter emit test2json-compatible NDJSON events
+	// instead of the raw text lines it would otherwise print or buffer.
+	json      bool
+	pkg       string    // current package, learned from the "pkg:" bootstrap marker
+	start     time.Time // when the filter first saw output, used for the package Elapsed
+	testStart map[string]time.Time // per-test start time, used for each test's Elapsed
+
+	benchjson  bool               // also emit each BenchmarkResult as a JSON line, for -benchjson
+	benchmarks []BenchmarkResult // every benchmark result line seen so far in text (non-JSON) mode
 }
 
 func NewConsoleFilter(quiet bool, output func(string)) *ConsoleFilter {
@@ -22,6 +33,28 @@ func NewConsoleFilter(quiet bool, output func(string)) *ConsoleFilter {
 	}
 }
 
+// SetJSON switches the filter into test2json NDJSON mode. It must be called before any
+// calls to Add so the synthesized "run" event for the first test isn't missed.
+func (cf *ConsoleFilter) SetJSON(json bool) {
+	cf.json = json
+	if json && cf.testStart == nil {
+		cf.testStart = make(map[string]time.Time)
+	}
+}
+
+// SetBenchJSON enables -benchjson mode: in addition to the normal always-print
+// benchmark line, each parsed BenchmarkResult is also emitted as a JSON line.
+func (cf *ConsoleFilter) SetBenchJSON(benchjson bool) {
+	cf.benchjson = benchjson
+}
+
+// Benchmarks returns every benchmark result line parsed so far, in the order they were
+// seen. Callers embedding wasmbrowsertest can use this instead of re-parsing stdout.
+// In JSON mode (SetJSON) benchmark lines are not parsed, so this stays empty.
+func (cf *ConsoleFilter) Benchmarks() []BenchmarkResult {
+	return cf.benchmarks
+}
+
 func (cf *ConsoleFilter) Add(input string) {
 	// Split input by newlines to ensure we handle line-by-line filtering
 	lines := strings.Split(input, "\n")
@@ -34,11 +67,85 @@ func (cf *ConsoleFilter) Add(input string) {
 			// Let's preserve for fidelity but filtering logic ignores empty lines usually.
 			// continue
 		}
+		if cf.json {
+			cf.addJSONLine(line)
+			continue
+		}
 		cf.addLine(line)
 	}
 }
 
+// addJSONLine converts a single line of browser console output into one or more
+// test2json events, following the same RUN/PASS/FAIL/SKIP/pkg: markers addLine already
+// recognizes. Free-text lines (logs interleaved between markers) become "output" events
+// attributed to the package; they carry no Test field, even while a test is running.
+func (cf *ConsoleFilter) addJSONLine(line string) {
+	if cf.start.IsZero() {
+		cf.start = time.Now()
+	}
+
+	if pkg, ok := strings.CutPrefix(line, "pkg:"); ok {
+		cf.pkg = strings.TrimSpace(pkg)
+		return
+	}
+
+	now := time.Now()
+
+	if testName, ok := parseRunLine(line); ok {
+		cf.testStart[testName] = now
+		cf.emit(testEvent{Time: now, Action: "run", Package: cf.pkg, Test: testName})
+		cf.emit(testEvent{Time: now, Action: "output", Package: cf.pkg, Test: testName, Output: line + "\n"})
+		return
+	}
+
+	if action, testName, ok := parseResultLine(line); ok {
+		cf.emit(testEvent{Time: now, Action: "output", Package: cf.pkg, Test: testName, Output: line + "\n"})
+		elapsed := now.Sub(cf.testStart[testName]).Seconds()
+		cf.emit(testEvent{Time: now, Action: action, Package: cf.pkg, Test: testName, Elapsed: elapsed})
+		delete(cf.testStart, testName)
+		return
+	}
+
+	trimmed := strings.TrimSpace(line)
+	switch trimmed {
+	case "PASS", "FAIL":
+		action := "pass"
+		if trimmed == "FAIL" {
+			action = "fail"
+		}
+		cf.emit(testEvent{Time: now, Action: "output", Package: cf.pkg, Output: line + "\n"})
+		cf.emit(testEvent{Time: now, Action: action, Package: cf.pkg, Elapsed: now.Sub(cf.start).Seconds()})
+		return
+	}
+
+	// Everything else (free-text logs, "ok", "coverage:", etc.) becomes an output event
+	// attributed to the package rather than a specific test.
+	cf.emit(testEvent{Time: now, Action: "output", Package: cf.pkg, Output: line + "\n"})
+}
+
+func (cf *ConsoleFilter) emit(ev testEvent) {
+	encodeTestEvent(ev, cf.output)
+}
+
 func (cf *ConsoleFilter) addLine(line string) {
+	// Benchmark result lines (and the goos:/goarch:/cpu: header lines printed just
+	// before them) are always-print: buffering or dropping them in quiet mode would
+	// make `go test -bench=. -benchmem` output unusable.
+	if result, ok := parseBenchmarkLine(line); ok {
+		cf.benchmarks = append(cf.benchmarks, result)
+		cf.Flush()
+		cf.output(line)
+		if cf.benchjson {
+			encodeBenchmarkJSON(result, cf.output)
+		}
+		return
+	}
+	if isBenchmarkHeaderLine(line) {
+		cf.Flush()
+		cf.output(line)
+		return
+	}
+
 	if !cf.quiet {
 		cf.output(line)
 		return
@@ -92,6 +92,84 @@ func TestConsoleFilter_Quiet(t *testing.T) {
 	}
 }
 
+func TestConsoleFilter_JSON(t *testing.T) {
+	var output []string
+	record := func(s string) {
+		output = append(output, s)
+	}
+
+	cf := NewConsoleFilter(false, record)
+	cf.SetJSON(true)
+
+	cf.Add("pkg: example.com/foo")
+	cf.Add("=== RUN   TestPass")
+	cf.Add("--- PASS: TestPass (0.01s)")
+	cf.Add("PASS")
+
+	// run, output(RUN line), output(PASS line), pass(test), output(PASS line), pass(package)
+	if len(output) != 6 {
+		t.Fatalf("Expected 6 NDJSON events, got %d: %v", len(output), output)
+	}
+
+	for i, line := range output {
+		if !strings.HasPrefix(line, "{") || !strings.HasSuffix(line, "}") {
+			t.Errorf("Line %d is not a JSON object: %q", i, line)
+		}
+		if !strings.Contains(line, `"Package":"example.com/foo"`) {
+			t.Errorf("Line %d missing Package field: %q", i, line)
+		}
+	}
+
+	if !strings.Contains(output[0], `"Action":"run"`) || !strings.Contains(output[0], `"Test":"TestPass"`) {
+		t.Errorf("Expected first event to be a run action for TestPass, got: %q", output[0])
+	}
+
+	if !strings.Contains(output[3], `"Action":"pass"`) || !strings.Contains(output[3], `"Elapsed"`) {
+		t.Errorf("Expected fourth event to be a pass action with Elapsed, got: %q", output[3])
+	}
+
+	if !strings.Contains(output[5], `"Action":"pass"`) || strings.Contains(output[5], `"Test"`) {
+		t.Errorf("Expected last event to be a package-level pass with no Test field, got: %q", output[5])
+	}
+}
+
+func TestConsoleFilter_Benchmark(t *testing.T) {
+	var output []string
+	record := func(s string) {
+		output = append(output, s)
+	}
+
+	cf := NewConsoleFilter(true, record) // quiet mode: benchmarks must still always-print
+
+	cf.Add("goos: linux")
+	cf.Add("goarch: amd64")
+	cf.Add("BenchmarkFoo-8   1000000   120.5 ns/op   16 B/op   1 allocs/op")
+	cf.Add("PASS")
+
+	if len(output) == 0 {
+		t.Fatal("Expected benchmark lines to always print in quiet mode, got none")
+	}
+
+	found := false
+	for _, line := range output {
+		if strings.Contains(line, "BenchmarkFoo-8") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected benchmark result line in output, got: %v", output)
+	}
+
+	results := cf.Benchmarks()
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 parsed benchmark result, got %d", len(results))
+	}
+	r := results[0]
+	if r.Name != "BenchmarkFoo-8" || r.N != 1000000 || r.NsPerOp != 120.5 || r.MemBytes != 16 || r.AllocsPerOp != 1 {
+		t.Errorf("Unexpected parsed benchmark result: %+v", r)
+	}
+}
+
 func TestConsoleFilter_Verbose(t *testing.T) {
 	var output []string
 	record := func(s string) {
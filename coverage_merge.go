@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// coverBlockKey identifies one coverage block the same way cmd/cover's profile format
+// does: a source range within a file. Two shards reporting the same key describe the
+// same statement block and must be merged, not treated as distinct entries.
+type coverBlockKey struct {
+	file                string
+	startLine, startCol int
+	endLine, endCol     int
+	numStmt             int
+}
+
+// mergeCoverProfiles merges the per-shard -test.coverprofile files produced by running
+// shards in separate browser contexts into a single profile at the standard
+// `mode: <mode>` header followed by `file:sl.sc,el.ec numStmt count` lines. Counts are
+// summed across shards, except under `mode: set` where a block is "covered" if any
+// shard covered it, so the merged count is clamped to 1.
+func mergeCoverProfiles(paths []string, out io.Writer) error {
+	var mode string
+	var order []coverBlockKey
+	counts := make(map[coverBlockKey]int)
+
+	for _, p := range paths {
+		if err := mergeOneCoverProfile(p, &mode, &order, counts); err != nil {
+			return fmt.Errorf("merging coverage profile %s: %w", p, err)
+		}
+	}
+
+	if mode == "" {
+		mode = "set"
+	}
+	if _, err := fmt.Fprintf(out, "mode: %s\n", mode); err != nil {
+		return err
+	}
+	for _, key := range order {
+		count := counts[key]
+		if mode == "set" && count > 1 {
+			count = 1
+		}
+		if _, err := fmt.Fprintf(out, "%s:%d.%d,%d.%d %d %d\n",
+			key.file, key.startLine, key.startCol, key.endLine, key.endCol, key.numStmt, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeOneCoverProfile(path string, mode *string, order *[]coverBlockKey, counts map[coverBlockKey]int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if m, ok := strings.CutPrefix(line, "mode: "); ok {
+			if *mode == "" {
+				*mode = m
+			} else if *mode != m {
+				return fmt.Errorf("mismatched coverage mode %q and %q across shards", *mode, m)
+			}
+			continue
+		}
+
+		key, count, err := parseCoverLine(line)
+		if err != nil {
+			return err
+		}
+		if _, seen := counts[key]; !seen {
+			*order = append(*order, key)
+		}
+		counts[key] += count
+	}
+	return scanner.Err()
+}
+
+// parseCoverLine parses a single "file:sl.sc,el.ec numStmt count" cover profile line.
+func parseCoverLine(line string) (coverBlockKey, int, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 {
+		return coverBlockKey{}, 0, fmt.Errorf("malformed cover profile line: %q", line)
+	}
+
+	idx := strings.LastIndex(fields[0], ":")
+	if idx < 0 {
+		return coverBlockKey{}, 0, fmt.Errorf("malformed cover profile line: %q", line)
+	}
+	file := fields[0][:idx]
+
+	var sl, sc, el, ec int
+	if _, err := fmt.Sscanf(fields[0][idx+1:], "%d.%d,%d.%d", &sl, &sc, &el, &ec); err != nil {
+		return coverBlockKey{}, 0, fmt.Errorf("malformed cover profile range in %q: %w", line, err)
+	}
+
+	numStmt, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return coverBlockKey{}, 0, fmt.Errorf("malformed numStmt in %q: %w", line, err)
+	}
+	count, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return coverBlockKey{}, 0, fmt.Errorf("malformed count in %q: %w", line, err)
+	}
+
+	return coverBlockKey{file: file, startLine: sl, startCol: sc, endLine: el, endCol: ec, numStmt: numStmt}, count, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCoverProfile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMergeCoverProfiles_Count(t *testing.T) {
+	dir := t.TempDir()
+	a := writeCoverProfile(t, dir, "a.out", "mode: count\n"+
+		"example.com/foo/bar.go:3.14,5.2 2 1\n"+
+		"example.com/foo/bar.go:7.2,9.3 1 0\n")
+	b := writeCoverProfile(t, dir, "b.out", "mode: count\n"+
+		"example.com/foo/bar.go:3.14,5.2 2 3\n"+
+		"example.com/foo/bar.go:7.2,9.3 1 1\n")
+
+	var buf strings.Builder
+	if err := mergeCoverProfiles([]string{a, b}, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "mode: count\n" +
+		"example.com/foo/bar.go:3.14,5.2 2 4\n" +
+		"example.com/foo/bar.go:7.2,9.3 1 1\n"
+	if buf.String() != want {
+		t.Errorf("mergeCoverProfiles() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestMergeCoverProfiles_Set(t *testing.T) {
+	dir := t.TempDir()
+	a := writeCoverProfile(t, dir, "a.out", "mode: set\n"+
+		"example.com/foo/bar.go:3.14,5.2 2 0\n")
+	b := writeCoverProfile(t, dir, "b.out", "mode: set\n"+
+		"example.com/foo/bar.go:3.14,5.2 2 1\n")
+
+	var buf strings.Builder
+	if err := mergeCoverProfiles([]string{a, b}, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "mode: set\nexample.com/foo/bar.go:3.14,5.2 2 1\n"
+	if buf.String() != want {
+		t.Errorf("mergeCoverProfiles() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestMergeCoverProfiles_MismatchedMode(t *testing.T) {
+	dir := t.TempDir()
+	a := writeCoverProfile(t, dir, "a.out", "mode: set\nexample.com/foo/bar.go:3.14,5.2 2 1\n")
+	b := writeCoverProfile(t, dir, "b.out", "mode: count\nexample.com/foo/bar.go:3.14,5.2 2 1\n")
+
+	var buf strings.Builder
+	if err := mergeCoverProfiles([]string{a, b}, &buf); err == nil {
+		t.Error("Expected an error merging profiles with mismatched modes, got nil")
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fuzzFlagPrefixes are the -test.fuzz* flags the WASM runtime already understands;
+// the shim only needs to notice them and ferry the corpus, not interpret their values.
+var fuzzFlagPrefixes = []string{
+	"-test.fuzz=",
+	"-test.fuzztime=",
+	"-test.fuzzminimizetime=",
+	"-test.fuzzcachedir=",
+}
+
+// fuzzTargetName returns the Fuzz function name passed via -test.fuzz=, and whether
+// fuzzing was requested at all, by scanning the already-parsed passon args.
+func fuzzTargetName(passonArgs []string) (name string, fuzzing bool) {
+	for _, arg := range passonArgs {
+		if rest, ok := strings.CutPrefix(arg, "-test.fuzz="); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// fuzzCacheDir returns the GOCACHE fuzz cache directory to use, preferring an explicit
+// -test.fuzzcachedir= override (same flag `go test` itself passes down) and otherwise
+// falling back to $GOCACHE/fuzz.
+func fuzzCacheDir(passonArgs []string) string {
+	for _, arg := range passonArgs {
+		if rest, ok := strings.CutPrefix(arg, "-test.fuzzcachedir="); ok {
+			return rest
+		}
+	}
+	if gocache := os.Getenv("GOCACHE"); gocache != "" {
+		return filepath.Join(gocache, "fuzz")
+	}
+	return filepath.Join(os.TempDir(), "go-fuzz-cache")
+}
+
+// corpusHandler serves and accepts the seed/discovered corpus for a single fuzz target
+// so the in-browser WASM runtime can read and write it the same way the native
+// testing package reads/writes testdata/fuzz/<Name> and the GOCACHE fuzz cache.
+//
+// It is mounted by WASMServer at /corpus/{name} alongside the existing test harness
+// routes.
+type corpusHandler struct {
+	name     string // the Fuzz function name, e.g. "FuzzParse"
+	workdir  string // directory containing go.mod / testdata
+	cacheDir string // fuzz cache directory, from fuzzCacheDir: -test.fuzzcachedir, $GOCACHE/fuzz, or a temp dir
+}
+
+func newCorpusHandler(name, workdir, cacheDir string) *corpusHandler {
+	return &corpusHandler{name: name, workdir: workdir, cacheDir: cacheDir}
+}
+
+func (h *corpusHandler) seedDir() string {
+	return filepath.Join(h.workdir, "testdata", "fuzz", h.name)
+}
+
+func (h *corpusHandler) cacheDirForName() string {
+	return filepath.Join(h.cacheDir, h.name)
+}
+
+// ServeHTTP implements both halves of the /corpus/{name} endpoint: GET streams every
+// file of the seed and cache corpus directories, ordered by full path, each as its
+// byte length on a line of its own followed by the file's contents; POST accepts one newly discovered interesting input and writes it into the
+// cache directory in the standard "go test fuzz v1" textual format.
+func (h *corpusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.serveCorpus(w, r)
+	case http.MethodPost:
+		h.receiveCorpusEntry(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *corpusHandler) serveCorpus(w http.ResponseWriter, r *http.Request) {
+	var files []string
+	for _, dir := range []string{h.seedDir(), h.cacheDirForName()} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(bw, "%d\n", len(data))
+		bw.Write(data)
+	}
+}
+
+// receiveCorpusEntry writes the request body into the fuzz cache directory under a
+// filename derived from its SHA256 hash, matching what `go test` itself does when it
+// finds a new interesting input.
+func (h *corpusHandler) receiveCorpusEntry(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir := h.cacheDirForName()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	name := hex.EncodeToString(sum[:])
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintln(w, name)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
@@ -53,6 +54,11 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 	cpuProfile := flagSet.String("test.cpuprofile", "", "")
 	coverageProfile := flagSet.String("test.coverprofile", "", "")
 	quiet := flagSet.Bool("quiet", false, "disable printing of passing test logs")
+	jsonOutput := flagSet.Bool("json", false, "emit test2json-compatible NDJSON events, like 'go test -json'")
+	benchJSON := flagSet.Bool("benchjson", false, "also emit each benchmark result as a JSON line")
+	parallelN := flagSet.Int("parallel", 1, "run tests across N concurrent browser contexts, sharded by test name")
+	shardSpec := flagSet.String("shard", "", "run only shard i/N of the tests (e.g. 0/4), instead of -parallel spawning all shards itself")
+	runtimeName := flagSet.String("runtime", "chrome", "execution backend: chrome (default), node, or wazero")
 
 	// Separate flags and other args
 	var wasmFile string
@@ -90,6 +96,8 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 	cleanArgs = append(cleanArgs, args[0])
 
 	foundQuiet := false
+	foundJSON := false
+	foundBenchJSON := false
 	for i, arg := range args {
 		if i == 0 {
 			continue
@@ -98,11 +106,37 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 			foundQuiet = true
 			continue
 		}
+		if arg == "-json" || arg == "--json" {
+			foundJSON = true
+			continue
+		}
+		if arg == "-benchjson" || arg == "--benchjson" {
+			foundBenchJSON = true
+			continue
+		}
+		if rest, ok := cutFlagValue(arg, "-parallel"); ok {
+			n, err := strconv.Atoi(rest)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid -parallel value %q", rest)
+			}
+			*parallelN = n
+			continue
+		}
+		if rest, ok := cutFlagValue(arg, "-shard"); ok {
+			*shardSpec = rest
+			continue
+		}
+		if rest, ok := cutFlagValue(arg, "-runtime"); ok {
+			*runtimeName = rest
+			continue
+		}
 		// Also strip cpuprofile/coverprofile if handled manually?
 		// No, let gentleParse and flagSet handle standard go test flags.
 		cleanArgs = append(cleanArgs, arg)
 	}
 	*quiet = foundQuiet
+	*jsonOutput = foundJSON
+	*benchJSON = foundBenchJSON
 
 	args = cleanArgs
 
@@ -176,12 +210,77 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 		passon = append(passon, "-test.cpuprofile="+*cpuProfile)
 	}
 
+	timeout, hasTimeout, err := testTimeout(passon)
+	if err != nil {
+		return err
+	}
+
+	if *shardSpec != "" {
+		shardIndex, shardCount, err := parseShardSpec(*shardSpec)
+		if err != nil {
+			return err
+		}
+		names, err := discoverTestNames(ctx, wasmFile, passon, logger)
+		if err != nil {
+			return fmt.Errorf("discovering tests for -shard %s: %w", *shardSpec, err)
+		}
+		shardTestNames := shardTests(names, shardIndex, shardCount)
+		if len(shardTestNames) == 0 {
+			// An empty -test.run= means "match everything" to the testing package,
+			// the opposite of what an empty shard should do. Nothing in this shard
+			// to run.
+			return nil
+		}
+		passon = append(passon, "-test.run="+testRunPattern(shardTestNames))
+	} else if *parallelN > 1 {
+		// Unlike -test.coverprofile, runParallelSessions has no way to merge the
+		// per-shard pprof output into one profile, and every shard writing the same
+		// -test.cpuprofile path would just clobber each other's profile. Reject the
+		// combination outright rather than silently dropping or corrupting it.
+		if *cpuProfile != "" {
+			return fmt.Errorf("-test.cpuprofile is not supported together with -parallel")
+		}
+		// runParallelSessions manages its own per-shard -test.coverprofile; drop the
+		// single shared path so shards don't race each other writing the same file.
+		var passonBase []string
+		for _, a := range passon {
+			if strings.HasPrefix(a, "-test.coverprofile=") {
+				continue
+			}
+			passonBase = append(passonBase, a)
+		}
+		return runParallelSessions(ctx, wasmFile, passonBase, *parallelN, *coverageProfile, *quiet, *jsonOutput, *benchJSON, logger)
+	}
+
+	if *runtimeName != "" && *runtimeName != "chrome" {
+		return runWithRuntime(ctx, *runtimeName, wasmFile, passon, timeout, hasTimeout, *quiet, *jsonOutput, *benchJSON, logger)
+	}
+
 	// Setup web server.
 	handler, err := NewWASMServer(wasmFile, passon, *coverageProfile, logger)
 	if err != nil {
 		return err
 	}
-	url, shutdownHTTPServer, err := startHTTPServer(ctx, handler, logger)
+
+	// If the test binary was invoked with -test.fuzz=, mount the corpus ferry routes
+	// the in-browser runtime uses to read the seed corpus and report back interesting
+	// inputs, in front of the regular test harness routes.
+	fuzzName, fuzzing := fuzzTargetName(passon)
+	var corpus *corpusHandler
+	httpHandler := handler
+	if fuzzing {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		corpus = newCorpusHandler(fuzzName, wd, fuzzCacheDir(passon))
+		mux := http.NewServeMux()
+		mux.Handle("/corpus/"+fuzzName, corpus)
+		mux.Handle("/", handler)
+		httpHandler = mux
+	}
+
+	url, shutdownHTTPServer, err := startHTTPServer(ctx, httpHandler, logger)
 	if err != nil {
 		return err
 	}
@@ -204,12 +303,23 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 	// create chrome instance
 	allocCtx, cancelAllocCtx := chromedp.NewExecAllocator(ctx, opts...)
 	defer cancelAllocCtx()
-	ctx, cancelCtx := chromedp.NewContext(allocCtx)
-	defer cancelCtx()
 
-	chromedp.ListenTarget(ctx, func(ev interface{}) {
-		handleEvent(ctx, ev, logger, *quiet)
-	})
+	crashed := make(chan struct{}, 1)
+	newTarget := func() (context.Context, context.CancelFunc) {
+		targetCtx, cancelTarget := chromedp.NewContext(allocCtx)
+		chromedp.ListenTarget(targetCtx, func(ev interface{}) {
+			handleEvent(targetCtx, ev, logger, *quiet, *jsonOutput, *benchJSON)
+			if _, ok := ev.(*target.EventTargetCrashed); ok && fuzzing {
+				select {
+				case crashed <- struct{}{}:
+				default:
+				}
+			}
+		})
+		return targetCtx, cancelTarget
+	}
+	ctx, cancelCtx := newTarget()
+	defer func() { cancelCtx() }()
 
 	var exitCode int
 	tasks := []chromedp.Action{
@@ -248,15 +358,32 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 		}))
 	}
 
-	err = chromedp.Run(ctx, tasks...)
-	if *quiet {
-		if consoleFilter == nil {
-			// Initialize if not done (though handleEvent should have done it if events fired)
-			consoleFilter = NewConsoleFilter(*quiet, func(s string) {
-				fmt.Printf("%s\n", s)
-			})
+	if fuzzing {
+		// A WASM trap inside the fuzz target takes the whole target down
+		// (target.EventTargetCrashed); bring up a fresh target and resume fuzzing
+		// instead of aborting the run.
+		const maxFuzzRestarts = 1000
+	restartLoop:
+		for restarts := 0; ; restarts++ {
+			err = runTasksWithTimeout(ctx, timeout, hasTimeout, tasks, logger, consoleFilterOrNew(*quiet, *jsonOutput, *benchJSON))
+			select {
+			case <-crashed:
+				if restarts >= maxFuzzRestarts {
+					break restartLoop
+				}
+				logger.Printf("chrome target crashed while fuzzing %s, restarting", fuzzName)
+				cancelCtx()
+				ctx, cancelCtx = newTarget()
+				continue restartLoop
+			default:
+				break restartLoop
+			}
 		}
-		consoleFilter.Flush()
+	} else {
+		err = runTasksWithTimeout(ctx, timeout, hasTimeout, tasks, logger, consoleFilterOrNew(*quiet, *jsonOutput, *benchJSON))
+	}
+	if *quiet {
+		consoleFilterOrNew(*quiet, *jsonOutput, *benchJSON).Flush()
 	}
 	if err != nil {
 		// Browser did not exit cleanly. Likely failed with an uncaught error.
@@ -268,6 +395,18 @@ func run(ctx context.Context, args []string, errOutput io.Writer, flagSet *flag.
 	return nil
 }
 
+// cutFlagValue matches an arg against "-name=value" or "--name=value" and returns the
+// value. It's used for the handful of wasmbrowsertest-specific exec flags (-parallel,
+// -shard) that take a value and must be stripped before the wasm file, same as -quiet.
+func cutFlagValue(arg, name string) (value string, ok bool) {
+	for _, prefix := range []string{name + "=", "-" + name + "="} {
+		if rest, ok := strings.CutPrefix(arg, prefix); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
 func copyFile(src, dst string) error {
 	srdFd, err := os.Open(src)
 	if err != nil {
@@ -293,13 +432,37 @@ var (
 	consoleFilter *ConsoleFilter
 )
 
-func handleEvent(ctx context.Context, ev interface{}, logger *log.Logger, quiet bool) {
+// consoleFilterOrNew returns the package-level ConsoleFilter, initializing it first if
+// no console event has arrived yet (e.g. the browser timed out before printing
+// anything). handleEvent does the same lazy init; this just covers the read side.
+func consoleFilterOrNew(quiet, jsonOutput, benchJSON bool) *ConsoleFilter {
 	if consoleFilter == nil {
 		consoleFilter = NewConsoleFilter(quiet, func(s string) {
 			fmt.Printf("%s\n", s)
 		})
+		consoleFilter.SetJSON(jsonOutput)
+		consoleFilter.SetBenchJSON(benchJSON)
 	}
+	return consoleFilter
+}
+
+func handleEvent(ctx context.Context, ev interface{}, logger *log.Logger, quiet, jsonOutput, benchJSON bool) {
+	if consoleFilter == nil {
+		consoleFilter = NewConsoleFilter(quiet, func(s string) {
+			fmt.Printf("%s\n", s)
+		})
+		consoleFilter.SetJSON(jsonOutput)
+		consoleFilter.SetBenchJSON(benchJSON)
+	}
+	dispatchConsoleEvent(ctx, ev, logger, consoleFilter)
+}
 
+// dispatchConsoleEvent handles a single CDP event against an explicit ConsoleFilter.
+// handleEvent is a thin wrapper around this for the default run (which a -shard run
+// also goes through); runBrowserSession, used by -parallel and for test discovery,
+// calls this directly with a per-session filter so concurrent browser contexts don't
+// trample each other's buffered state.
+func dispatchConsoleEvent(ctx context.Context, ev interface{}, logger *log.Logger, cf *ConsoleFilter) {
 	switch ev := ev.(type) {
 	case *cdpruntime.EventConsoleAPICalled:
 		for _, arg := range ev.Args {
@@ -316,11 +479,11 @@ func handleEvent(ctx context.Context, ev interface{}, logger *log.Logger, quiet
 				s = line
 			}
 
-			consoleFilter.Add(s)
+			cf.Add(s)
 		}
 	case *cdpruntime.EventExceptionThrown:
 		if ev.ExceptionDetails != nil {
-			consoleFilter.Flush()
+			cf.Flush()
 			details := ev.ExceptionDetails
 			fmt.Printf("%s:%d:%d %s\n", details.URL, details.LineNumber, details.ColumnNumber, details.Text)
 			if details.Exception != nil {
@@ -328,14 +491,14 @@ func handleEvent(ctx context.Context, ev interface{}, logger *log.Logger, quiet
 			}
 		}
 	case *target.EventTargetCrashed:
-		consoleFilter.Flush()
+		cf.Flush()
 		fmt.Printf("target crashed: status: %s, error code:%d\n", ev.Status, ev.ErrorCode)
 		err := chromedp.Cancel(ctx)
 		if err != nil {
 			logger.Printf("error in cancelling context: %v\n", err)
 		}
 	case *inspector.EventDetached:
-		consoleFilter.Flush()
+		cf.Flush()
 		fmt.Println("inspector detached: ", ev.Reason)
 		err := chromedp.Cancel(ctx)
 		if err != nil {
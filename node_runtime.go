@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// nodeRuntime runs a js/wasm test binary under Node, via the same wasm_exec_node.js
+// shim the Go toolchain ships at $(go env GOROOT)/misc/wasm/wasm_exec_node.js, for CI
+// environments that have Node installed but no browser.
+type nodeRuntime struct {
+	cmd     *exec.Cmd
+	console chan string
+	done    chan struct{}
+	err     error
+}
+
+func newNodeRuntime(wasmFile string, passon []string, logger *log.Logger) (*nodeRuntime, error) {
+	shim, err := wasmExecNodeShimPath()
+	if err != nil {
+		return nil, err
+	}
+
+	// passon[0] is wasmFile itself (same convention NewWASMServer uses); everything
+	// after it is test flags to forward to the binary.
+	var testArgs []string
+	if len(passon) > 1 {
+		testArgs = passon[1:]
+	}
+	args := append([]string{shim, wasmFile}, testArgs...)
+
+	cmd := exec.Command("node", args...)
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &nodeRuntime{cmd: cmd, console: make(chan string, 64), done: make(chan struct{})}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	stream := func(rd io.Reader) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(rd)
+		for scanner.Scan() {
+			r.console <- scanner.Text()
+		}
+	}
+	go stream(stdout)
+	go stream(stderr)
+
+	go func() {
+		wg.Wait()
+		r.err = cmd.Wait()
+		close(r.console)
+		close(r.done)
+	}()
+
+	return r, nil
+}
+
+// Start is a no-op: newNodeRuntime already launched the process, since Node has no
+// page for a URL to navigate to. url is ignored.
+func (r *nodeRuntime) Start(ctx context.Context, url string) error {
+	return nil
+}
+
+func (r *nodeRuntime) WaitDone(ctx context.Context) (int, error) {
+	select {
+	case <-r.done:
+		if r.err != nil {
+			if exitErr, ok := r.err.(*exec.ExitError); ok {
+				return exitErr.ExitCode(), nil
+			}
+			return 0, r.err
+		}
+		return 0, nil
+	case <-ctx.Done():
+		if r.cmd.Process != nil {
+			r.cmd.Process.Kill()
+		}
+		return 0, ctx.Err()
+	}
+}
+
+func (r *nodeRuntime) Console() <-chan string {
+	return r.console
+}
+
+// wasmExecNodeShimPath locates wasm_exec_node.js the same way `go test` with
+// GOOS=js GOARCH=wasm would: under $(go env GOROOT)/misc/wasm.
+func wasmExecNodeShimPath() (string, error) {
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return "", fmt.Errorf("locating GOROOT for wasm_exec_node.js: %w", err)
+	}
+	goroot := strings.TrimSpace(string(out))
+	shim := filepath.Join(goroot, "misc", "wasm", "wasm_exec_node.js")
+	if _, err := os.Stat(shim); err != nil {
+		return "", fmt.Errorf("wasm_exec_node.js not found at %s: %w", shim, err)
+	}
+	return shim, nil
+}
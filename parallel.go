@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/chromedp/chromedp"
+)
+
+// runParallelSessions runs the given wasm test binary across shardCount concurrent
+// chromedp browser contexts, each served by its own WASMServer, and merges their
+// -test.coverprofile output into one file at coverageProfile. Test names are
+// discovered with one throwaway -test.list=.* session and then hash-partitioned, the
+// same way shardTests partitions a -shard i/N invocation.
+//
+// Each shard gets its own ConsoleFilter so concurrent "=== RUN" lines from different
+// browsers can't corrupt each other's PASS-removal bookkeeping; the filters all share
+// outputMu so each printed line is written whole. Lines from different shards can
+// still interleave with one another, including during a flush.
+func runParallelSessions(ctx context.Context, wasmFile string, passonBase []string, shardCount int, coverageProfile string, quiet, jsonOutput, benchJSON bool, logger *log.Logger) error {
+	names, err := discoverTestNames(ctx, wasmFile, passonBase, logger)
+	if err != nil {
+		return fmt.Errorf("discovering tests to shard: %w", err)
+	}
+
+	var coverDir string
+	if coverageProfile != "" {
+		coverDir, err = os.MkdirTemp("", "wasmbrowsertest-shard-cover-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(coverDir)
+	}
+
+	var outputMu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, shardCount)
+	var coverFilesMu sync.Mutex
+	var coverFiles []string
+
+	for shard := 0; shard < shardCount; shard++ {
+		shard := shard
+		shardTestNames := shardTests(names, shard, shardCount)
+		if len(shardTestNames) == 0 {
+			continue
+		}
+
+		passon := append(append([]string{}, passonBase...), "-test.run="+testRunPattern(shardTestNames))
+
+		var shardCoverProfile string
+		if coverageProfile != "" {
+			shardCoverProfile = filepath.Join(coverDir, fmt.Sprintf("shard-%d.out", shard))
+			passon = append(passon, "-test.coverprofile="+shardCoverProfile)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cf := NewConsoleFilter(quiet, func(s string) {
+				outputMu.Lock()
+				defer outputMu.Unlock()
+				if jsonOutput {
+					// A shard prefix would make the line invalid NDJSON.
+					fmt.Printf("%s\n", s)
+					return
+				}
+				fmt.Printf("[shard %d] %s\n", shard, s)
+			})
+			cf.SetJSON(jsonOutput)
+			cf.SetBenchJSON(benchJSON)
+
+			errs[shard] = runBrowserSession(ctx, wasmFile, passon, logger, cf)
+			if errs[shard] == nil {
+				cf.Flush()
+			}
+
+			if shardCoverProfile != "" {
+				if _, statErr := os.Stat(shardCoverProfile); statErr == nil {
+					coverFilesMu.Lock()
+					coverFiles = append(coverFiles, shardCoverProfile)
+					coverFilesMu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	if coverageProfile != "" && len(coverFiles) > 0 {
+		out, err := os.Create(coverageProfile)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		if err := mergeCoverProfiles(coverFiles, out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// discoverTestNames runs the wasm binary once with -test.list=.* to enumerate test
+// names without executing them, so they can be hash-partitioned into shards.
+func discoverTestNames(ctx context.Context, wasmFile string, passonBase []string, logger *log.Logger) ([]string, error) {
+	var mu sync.Mutex
+	var lines []string
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, s)
+	}
+
+	cf := NewConsoleFilter(false, record)
+	passon := append(append([]string{}, passonBase...), "-test.list=.*")
+	if err := runBrowserSession(ctx, wasmFile, passon, logger, cf); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		// -test.list output is one bare identifier per line; skip anything else
+		// (blank lines, a trailing "ok" summary, etc.) that isn't a plain name.
+		if line == "" || strings.ContainsAny(line, " \t:") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, nil
+}
+
+// runBrowserSession starts a WASMServer for wasmFile/passon and drives it with a single
+// chromedp browser context until #doneButton is enabled, routing every CDP console
+// event through cf. It doesn't handle -test.fuzz* crash recovery or -test.cpuprofile
+// capture; run() uses a richer variant of this same setup for the non-sharded path.
+func runBrowserSession(ctx context.Context, wasmFile string, passon []string, logger *log.Logger, cf *ConsoleFilter) error {
+	handler, err := NewWASMServer(wasmFile, passon, "", logger)
+	if err != nil {
+		return err
+	}
+	url, shutdownHTTPServer, err := startHTTPServer(ctx, handler, logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownHTTPServer()
+
+	opts := chromedp.DefaultExecAllocatorOptions[:]
+	if os.Getenv("WASM_HEADLESS") == "off" {
+		opts = append(opts, chromedp.Flag("headless", false))
+	}
+	if runtime.GOOS == "linux" && isWSL() {
+		opts = append(opts, chromedp.DisableGPU)
+	}
+
+	allocCtx, cancelAllocCtx := chromedp.NewExecAllocator(ctx, opts...)
+	defer cancelAllocCtx()
+	targetCtx, cancelCtx := chromedp.NewContext(allocCtx)
+	defer cancelCtx()
+
+	chromedp.ListenTarget(targetCtx, func(ev interface{}) {
+		dispatchConsoleEvent(targetCtx, ev, logger, cf)
+	})
+
+	var exitCode int
+	err = chromedp.Run(targetCtx,
+		chromedp.Navigate(url),
+		chromedp.WaitEnabled(`#doneButton`),
+		chromedp.Evaluate(`exitCode;`, &exitCode),
+	)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("exit with status %d", exitCode)
+	}
+	return nil
+}
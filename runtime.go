@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Runtime abstracts how the -runtime=node and -runtime=wazero backends execute a
+// compiled WASM test binary and collect its output, so run() can drive either one
+// through the same Start/WaitDone/Console contract instead of a bespoke code path per
+// backend. -runtime=chrome (the default) is not a Runtime implementation: it remains
+// run()'s original inline chromedp path, which alone supports -test.cpuprofile and
+// -test.fuzz* crash recovery; node and wazero trade those away for not needing a
+// browser install at all, which matters for minimal CI images.
+type Runtime interface {
+	// Start begins executing the test binary. url is unused by these backends (they
+	// don't go through a browser) and is kept only for symmetry with how a browser
+	// backend would use it.
+	Start(ctx context.Context, url string) error
+	// WaitDone blocks until the binary finishes and reports its exit code.
+	WaitDone(ctx context.Context) (exitCode int, err error)
+	// Console streams each line of output as the binary produces it. It is closed
+	// once WaitDone returns.
+	Console() <-chan string
+}
+
+// newRuntime constructs the Runtime backend named by -runtime. It is never called with
+// "" or "chrome": run() only calls it for the node/wazero backends and keeps driving
+// headless Chrome through its own inline path otherwise.
+func newRuntime(name, wasmFile string, passon []string, logger *log.Logger) (Runtime, error) {
+	switch name {
+	case "node":
+		return newNodeRuntime(wasmFile, passon, logger)
+	case "wazero":
+		return newWazeroRuntime(wasmFile, passon, logger)
+	default:
+		return nil, fmt.Errorf("unknown -runtime %q (want chrome, node, or wazero)", name)
+	}
+}
+
+// isWasip1Binary reports whether a WASM module imports wasi_snapshot_preview1 (built
+// with GOOS=wasip1) rather than the "go"/"gojs" imports js/wasm test binaries use. Only
+// wasip1 binaries can run under the wazero backend.
+func isWasip1Binary(wasmFile string) (bool, error) {
+	imports, err := wasmModuleImportModules(wasmFile)
+	if err != nil {
+		return false, err
+	}
+	for _, mod := range imports {
+		if mod == "wasi_snapshot_preview1" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
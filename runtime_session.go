@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// runWithRuntime drives the -runtime=node and -runtime=wazero backends through the
+// shared Runtime interface: start it, pump its console output through a ConsoleFilter
+// same as the chrome path does, and translate its exit code/timeout into the same
+// errors run()'s default chrome path would return. -test.cpuprofile and -test.fuzz*
+// remain chrome-only for now; coverage is produced by the test binary itself via
+// -test.coverprofile in passon, same as any other backend.
+func runWithRuntime(ctx context.Context, runtimeName, wasmFile string, passon []string, timeout time.Duration, hasTimeout bool, quiet, jsonOutput, benchJSON bool, logger *log.Logger) error {
+	rt, err := newRuntime(runtimeName, wasmFile, passon, logger)
+	if err != nil {
+		return err
+	}
+
+	runCtx := ctx
+	if hasTimeout {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := rt.Start(runCtx, ""); err != nil {
+		return err
+	}
+
+	cf := NewConsoleFilter(quiet, func(s string) {
+		fmt.Printf("%s\n", s)
+	})
+	cf.SetJSON(jsonOutput)
+	cf.SetBenchJSON(benchJSON)
+
+	consoleDone := make(chan struct{})
+	go func() {
+		defer close(consoleDone)
+		for line := range rt.Console() {
+			cf.Add(line)
+		}
+	}()
+
+	exitCode, waitErr := rt.WaitDone(runCtx)
+	<-consoleDone
+
+	if hasTimeout && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		cf.Flush()
+		fmt.Println(timeoutBanner(timeout, ""))
+		return fmt.Errorf("test timed out after %s", timeout)
+	}
+
+	if quiet {
+		cf.Flush()
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("exit with status %d", exitCode)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// shardTests partitions testNames across shardCount shards by hashing each name, the
+// same strategy cmd/go's test sharding support uses: a name always lands in the same
+// shard regardless of run order, so re-running one shard in isolation is reproducible.
+func shardTests(testNames []string, shardIndex, shardCount int) []string {
+	if shardCount <= 1 {
+		return testNames
+	}
+	var mine []string
+	for _, name := range testNames {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		if int(h.Sum32()%uint32(shardCount)) == shardIndex {
+			mine = append(mine, name)
+		}
+	}
+	sort.Strings(mine)
+	return mine
+}
+
+// parseShardSpec parses a "-shard i/N" value (0-based index, 1-based count) as used by
+// -shard.
+func parseShardSpec(spec string) (index, count int, err error) {
+	i, n, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid -shard value %q, want \"i/N\"", spec)
+	}
+	index, err = strconv.Atoi(i)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -shard index %q: %w", i, err)
+	}
+	count, err = strconv.Atoi(n)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -shard count %q: %w", n, err)
+	}
+	if count < 1 || index < 0 || index >= count {
+		return 0, 0, fmt.Errorf("-shard index %d out of range for count %d", index, count)
+	}
+	return index, count, nil
+}
+
+// testRunPattern builds a `-test.run=` value that matches exactly the given test names,
+// anchored so it can't accidentally match a differently-named test that merely shares a
+// prefix.
+func testRunPattern(testNames []string) string {
+	quoted := make([]string, len(testNames))
+	for i, name := range testNames {
+		quoted[i] = "^" + name + "$"
+	}
+	return strings.Join(quoted, "|")
+}
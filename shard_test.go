@@ -0,0 +1,90 @@
+package main
+
+import "testing"
+
+func TestShardTests(t *testing.T) {
+	names := []string{"TestA", "TestB", "TestC", "TestD", "TestE", "TestF"}
+
+	var total []string
+	seen := make(map[string]int)
+	const shardCount = 3
+	for i := 0; i < shardCount; i++ {
+		shard := shardTests(names, i, shardCount)
+		for _, name := range shard {
+			seen[name]++
+		}
+		total = append(total, shard...)
+	}
+
+	if len(total) != len(names) {
+		t.Fatalf("Expected every test to land in exactly one shard, got %d assignments for %d tests", len(total), len(names))
+	}
+	for _, name := range names {
+		if seen[name] != 1 {
+			t.Errorf("Expected %q to be assigned to exactly 1 shard, got %d", name, seen[name])
+		}
+	}
+
+	// Sharding must be deterministic across calls.
+	for i := 0; i < shardCount; i++ {
+		if a, b := shardTests(names, i, shardCount), shardTests(names, i, shardCount); !stringsEqual(a, b) {
+			t.Errorf("shardTests(%d) is not deterministic: %v != %v", i, a, b)
+		}
+	}
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTestRunPattern_Empty(t *testing.T) {
+	// An empty -test.run= value means "match everything" to the testing package, so
+	// an empty shard must never produce one; callers are expected to special-case
+	// this return value instead of passing it straight through as -test.run=.
+	if got := testRunPattern(nil); got != "" {
+		t.Errorf("testRunPattern(nil) = %q, want empty string", got)
+	}
+	if got := testRunPattern([]string{}); got != "" {
+		t.Errorf("testRunPattern([]string{}) = %q, want empty string", got)
+	}
+}
+
+func TestParseShardSpec(t *testing.T) {
+	tests := []struct {
+		spec      string
+		wantIndex int
+		wantCount int
+		wantErr   bool
+	}{
+		{spec: "0/4", wantIndex: 0, wantCount: 4},
+		{spec: "3/4", wantIndex: 3, wantCount: 4},
+		{spec: "4/4", wantErr: true},
+		{spec: "bad", wantErr: true},
+		{spec: "-1/4", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		index, count, err := parseShardSpec(tc.spec)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseShardSpec(%q): expected error, got index=%d count=%d", tc.spec, index, count)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseShardSpec(%q): unexpected error: %v", tc.spec, err)
+			continue
+		}
+		if index != tc.wantIndex || count != tc.wantCount {
+			t.Errorf("parseShardSpec(%q) = (%d, %d), want (%d, %d)", tc.spec, index, count, tc.wantIndex, tc.wantCount)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// testEvent mirrors the NDJSON shape emitted by `go tool test2json` / `go test -json`.
+// Fields are ordered and tagged to match cmd/internal/test2json exactly so downstream
+// consumers (gotestsum, IDEs, CI aggregators) don't need a separate parser.
+type testEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package,omitempty"`
+	Test    string    `json:"Test,omitempty"`
+	Elapsed float64   `json:"Elapsed,omitempty"`
+	Output  string    `json:"Output,omitempty"`
+}
+
+// encodeTestEvent writes ev as a single line of NDJSON via output.
+func encodeTestEvent(ev testEvent, output func(string)) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		// Marshaling a testEvent is not expected to fail; if it ever does, the
+		// event is dropped without being written.
+		return
+	}
+	output(string(b))
+}
+
+// parseRunLine extracts the test name from a "=== RUN   TestName" line, including
+// the "=== RUN   TestName/SubTest" form used for subtests.
+func parseRunLine(line string) (testName string, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) >= 3 && fields[0] == "===" && fields[1] == "RUN" {
+		return fields[2], true
+	}
+	return "", false
+}
+
+// parseResultLine extracts the action ("pass", "fail" or "skip") and test name from a
+// "--- PASS: TestName (0.00s)" style line.
+func parseResultLine(line string) (action, testName string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+	fields := strings.Fields(trimmed)
+	if len(fields) < 3 || fields[0] != "---" {
+		return "", "", false
+	}
+	switch fields[1] {
+	case "PASS:":
+		action = "pass"
+	case "FAIL:":
+		action = "fail"
+	case "SKIP:":
+		action = "skip"
+	default:
+		return "", "", false
+	}
+	return action, fields[2], true
+}
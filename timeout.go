@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// testTimeout extracts -test.timeout=<d> from passonArgs, the same flag
+// cmd/go/internal/test passes down to every test binary it execs.
+func testTimeout(passonArgs []string) (d time.Duration, ok bool, err error) {
+	for _, arg := range passonArgs {
+		if rest, found := strings.CutPrefix(arg, "-test.timeout="); found {
+			d, err = time.ParseDuration(rest)
+			if err != nil {
+				return 0, false, fmt.Errorf("invalid -test.timeout value %q: %w", rest, err)
+			}
+			return d, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// runTasksWithTimeout runs tasks under ctx like a plain chromedp.Run, except that when
+// timeout has elapsed it grabs a goroutine dump from the browser, flushes cf, prints a
+// banner mimicking the stdlib's own "panic: test timed out after <d>", and returns an
+// error so the caller exits non-zero instead of hanging until Ctrl-C.
+//
+// The goroutine dump is collected on ctx itself, not the expired timeoutCtx:
+// context.WithTimeout only marks its own child Done when the deadline fires, so the
+// target attached to ctx is still live and is the only context with a Target to
+// evaluate the stack-dump helper against.
+func runTasksWithTimeout(ctx context.Context, timeout time.Duration, hasTimeout bool, tasks []chromedp.Action, logger *log.Logger, cf *ConsoleFilter) error {
+	if !hasTimeout {
+		return chromedp.Run(ctx, tasks...)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := chromedp.Run(timeoutCtx, tasks...)
+	if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		dump := dumpBrowserStacks(ctx, logger)
+		cf.Flush()
+		fmt.Println(timeoutBanner(timeout, dump))
+		return fmt.Errorf("test timed out after %s", timeout)
+	}
+	return err
+}
+
+// dumpBrowserStacks asks the bootstrap page for a goroutine dump, the same one a real
+// test timeout panic would print, via a small helper the bootstrap page is expected to
+// expose. If it isn't there (older harness, or the crash already took the page down),
+// this degrades to an empty dump rather than failing the whole timeout report.
+func dumpBrowserStacks(ctx context.Context, logger *log.Logger) string {
+	var dump string
+	err := chromedp.Run(ctx, chromedp.Evaluate(`
+		(function() {
+			if (typeof __wasmbrowsertest_stackdump === "function") {
+				return __wasmbrowsertest_stackdump();
+			}
+			return "";
+		})()
+	`, &dump))
+	if err != nil {
+		logger.Printf("could not collect goroutine dump after timeout: %v", err)
+		return ""
+	}
+	return dump
+}
+
+// timeoutBanner mimics the stdlib testing package's own timeout panic message so
+// editors/CI tooling that already scrape for "panic: test timed out after" keep
+// working unmodified.
+func timeoutBanner(d time.Duration, dump string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "panic: test timed out after %s\n", d)
+	if dump != "" {
+		b.WriteString("\n")
+		b.WriteString(dump)
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTestTimeout(t *testing.T) {
+	d, ok, err := testTimeout([]string{"foo.wasm", "-test.v", "-test.timeout=45s"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || d != 45*time.Second {
+		t.Errorf("testTimeout() = (%v, %v), want (45s, true)", d, ok)
+	}
+
+	if _, ok, err := testTimeout([]string{"foo.wasm", "-test.v"}); ok || err != nil {
+		t.Errorf("expected no timeout and no error when -test.timeout is absent, got ok=%v err=%v", ok, err)
+	}
+
+	if _, _, err := testTimeout([]string{"-test.timeout=notaduration"}); err == nil {
+		t.Error("expected an error for an invalid -test.timeout value")
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// wasmModuleImportModules returns the distinct module names a WASM binary's import
+// section references (e.g. "go", "gojs", "wasi_snapshot_preview1"), by walking the
+// binary's section table directly rather than pulling in a full WASM decoder. This is
+// enough to tell a js/wasm test binary (imports from "go"/"gojs") apart from a wasip1
+// one (imports from "wasi_snapshot_preview1") for -runtime=wazero.
+func wasmModuleImportModules(wasmFile string) ([]string, error) {
+	data, err := os.ReadFile(wasmFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 8 || string(data[:4]) != "\x00asm" {
+		return nil, fmt.Errorf("%s is not a WASM binary", wasmFile)
+	}
+	buf := data[8:]
+
+	const importSectionID = 2
+	for len(buf) > 0 {
+		sectionID := buf[0]
+		buf = buf[1:]
+
+		size, n, err := readVarUint32(buf)
+		if err != nil {
+			return nil, fmt.Errorf("reading section size: %w", err)
+		}
+		buf = buf[n:]
+		if uint64(len(buf)) < uint64(size) {
+			return nil, fmt.Errorf("truncated section in %s", wasmFile)
+		}
+		payload := buf[:size]
+		buf = buf[size:]
+
+		if sectionID == importSectionID {
+			return parseImportModules(payload)
+		}
+	}
+	return nil, nil // no import section: a module with no imports at all
+}
+
+func parseImportModules(payload []byte) ([]string, error) {
+	count, n, err := readVarUint32(payload)
+	if err != nil {
+		return nil, err
+	}
+	payload = payload[n:]
+
+	seen := make(map[string]bool)
+	var modules []string
+	for i := uint32(0); i < count; i++ {
+		mod, rest, err := readWasmString(payload)
+		if err != nil {
+			return nil, err
+		}
+		_, rest, err = readWasmString(rest) // field name, unused
+		if err != nil {
+			return nil, err
+		}
+		rest, err = skipImportDescriptor(rest)
+		if err != nil {
+			return nil, err
+		}
+		payload = rest
+
+		if !seen[mod] {
+			seen[mod] = true
+			modules = append(modules, mod)
+		}
+	}
+	return modules, nil
+}
+
+// skipImportDescriptor consumes one import's kind-specific encoding (func type index,
+// table type, memory limits, or global type) and returns what's left of buf.
+func skipImportDescriptor(buf []byte) ([]byte, error) {
+	if len(buf) < 1 {
+		return nil, fmt.Errorf("truncated import descriptor")
+	}
+	kind := buf[0]
+	buf = buf[1:]
+
+	switch kind {
+	case 0x00: // func: typeidx
+		_, n, err := readVarUint32(buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[n:], nil
+	case 0x01: // table: reftype byte + limits
+		if len(buf) < 1 {
+			return nil, fmt.Errorf("truncated table import")
+		}
+		return skipLimits(buf[1:])
+	case 0x02: // memory: limits
+		return skipLimits(buf)
+	case 0x03: // global: valtype byte + mutability byte
+		if len(buf) < 2 {
+			return nil, fmt.Errorf("truncated global import")
+		}
+		return buf[2:], nil
+	default:
+		return nil, fmt.Errorf("unknown import kind %#x", kind)
+	}
+}
+
+func skipLimits(buf []byte) ([]byte, error) {
+	if len(buf) < 1 {
+		return nil, fmt.Errorf("truncated limits")
+	}
+	hasMax := buf[0] == 1
+	buf = buf[1:]
+	_, n, err := readVarUint32(buf)
+	if err != nil {
+		return nil, err
+	}
+	buf = buf[n:]
+	if hasMax {
+		_, n, err := readVarUint32(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[n:]
+	}
+	return buf, nil
+}
+
+func readWasmString(buf []byte) (string, []byte, error) {
+	length, n, err := readVarUint32(buf)
+	if err != nil {
+		return "", nil, err
+	}
+	buf = buf[n:]
+	if uint64(len(buf)) < uint64(length) {
+		return "", nil, fmt.Errorf("truncated string")
+	}
+	return string(buf[:length]), buf[length:], nil
+}
+
+// readVarUint32 decodes a LEB128-encoded unsigned 32-bit integer, as used throughout
+// the WASM binary format, returning the value and how many bytes it consumed.
+func readVarUint32(buf []byte) (uint32, int, error) {
+	v, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return 0, 0, fmt.Errorf("malformed varuint32")
+	}
+	return uint32(v), n, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildTestWasmModule assembles a minimal (and otherwise invalid) WASM binary
+// containing just an import section, enough to exercise wasmModuleImportModules
+// without needing a real compiler toolchain in the test environment.
+func buildTestWasmModule(t *testing.T, imports [][2]string) []byte {
+	t.Helper()
+
+	var payload bytes.Buffer
+	writeVarUint32(&payload, uint32(len(imports)))
+	for _, imp := range imports {
+		writeWasmString(&payload, imp[0])
+		writeWasmString(&payload, imp[1])
+		payload.WriteByte(0x00) // func import
+		writeVarUint32(&payload, 0)
+	}
+
+	var mod bytes.Buffer
+	mod.WriteString("\x00asm")
+	mod.Write([]byte{0x01, 0x00, 0x00, 0x00})
+	mod.WriteByte(2) // import section id
+	writeVarUint32(&mod, uint32(payload.Len()))
+	mod.Write(payload.Bytes())
+
+	return mod.Bytes()
+}
+
+func writeVarUint32(buf *bytes.Buffer, v uint32) {
+	var tmp [binary.MaxVarintLen32]byte
+	n := binary.PutUvarint(tmp[:], uint64(v))
+	buf.Write(tmp[:n])
+}
+
+func writeWasmString(buf *bytes.Buffer, s string) {
+	writeVarUint32(buf, uint32(len(s)))
+	buf.WriteString(s)
+}
+
+func TestWasmModuleImportModules(t *testing.T) {
+	data := buildTestWasmModule(t, [][2]string{
+		{"wasi_snapshot_preview1", "fd_write"},
+		{"wasi_snapshot_preview1", "fd_read"},
+		{"env", "custom_fn"},
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.wasm")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := wasmModuleImportModules(path)
+	if err != nil {
+		t.Fatalf("wasmModuleImportModules() error: %v", err)
+	}
+
+	want := []string{"wasi_snapshot_preview1", "env"}
+	if len(modules) != len(want) {
+		t.Fatalf("got modules %v, want %v", modules, want)
+	}
+	for i, m := range want {
+		if modules[i] != m {
+			t.Errorf("modules[%d] = %q, want %q", i, modules[i], m)
+		}
+	}
+}
+
+func TestIsWasip1Binary(t *testing.T) {
+	dir := t.TempDir()
+
+	wasip1Path := filepath.Join(dir, "wasip1.wasm")
+	if err := os.WriteFile(wasip1Path, buildTestWasmModule(t, [][2]string{{"wasi_snapshot_preview1", "fd_write"}}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gojsPath := filepath.Join(dir, "gojs.wasm")
+	if err := os.WriteFile(gojsPath, buildTestWasmModule(t, [][2]string{{"gojs", "runtime.wasmExit"}}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := isWasip1Binary(wasip1Path); err != nil || !ok {
+		t.Errorf("isWasip1Binary(wasip1) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if ok, err := isWasip1Binary(gojsPath); err != nil || ok {
+		t.Errorf("isWasip1Binary(gojs) = (%v, %v), want (false, nil)", ok, err)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// wazeroRuntime hosts a GOOS=wasip1 test binary directly via the wazero embedding API
+// with wasi_snapshot_preview1 satisfied, so headless CI needs neither a browser nor a
+// Node install for WASI-targeted test binaries.
+type wazeroRuntime struct {
+	wasmFile string
+	args     []string
+	console  chan string
+	done     chan struct{}
+	exitCode int
+	err      error
+}
+
+func newWazeroRuntime(wasmFile string, passon []string, logger *log.Logger) (*wazeroRuntime, error) {
+	ok, err := isWasip1Binary(wasmFile)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s does not import wasi_snapshot_preview1; -runtime=wazero only supports GOOS=wasip1 test binaries", wasmFile)
+	}
+	return &wazeroRuntime{
+		wasmFile: wasmFile,
+		args:     passon,
+		console:  make(chan string, 64),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Start runs the module in a background goroutine and returns at once; WaitDone waits
+// for it to finish. wazero has no notion of navigating to a URL, so url is ignored.
+func (r *wazeroRuntime) Start(ctx context.Context, url string) error {
+	go r.run(ctx)
+	return nil
+}
+
+func (r *wazeroRuntime) run(ctx context.Context) {
+	defer close(r.console)
+	defer close(r.done)
+
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		r.err = fmt.Errorf("instantiating wasi_snapshot_preview1: %w", err)
+		return
+	}
+
+	wasmBytes, err := os.ReadFile(r.wasmFile)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	stdoutR, stdoutW := io.Pipe()
+	streamDone := make(chan struct{})
+	go func() {
+		defer close(streamDone)
+		r.streamLines(stdoutR)
+	}()
+
+	cfg := wazero.NewModuleConfig().
+		WithArgs(append([]string{r.wasmFile}, r.args...)...).
+		WithStdout(stdoutW).
+		WithStderr(stdoutW).
+		WithRandSource(rand.Reader).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithSysNanosleep().
+		// Without this, a hung/looping module keeps running after ctx is canceled
+		// (e.g. by -test.timeout in runWithRuntime), and nothing ever unblocks the
+		// caller waiting on r.done/r.console. With it, wazero force-closes the
+		// module's store as soon as ctx.Done() fires, so InstantiateWithConfig
+		// below returns promptly with a sys.ExitError instead of never returning.
+		WithCloseOnContextDone(true)
+
+	mod, instErr := rt.InstantiateWithConfig(ctx, wasmBytes, cfg)
+	stdoutW.Close()
+	// Let streamLines deliver its last lines before the deferred close(r.console).
+	<-streamDone
+	if mod != nil {
+		defer mod.Close(ctx)
+	}
+
+	if instErr != nil {
+		var exitErr *sys.ExitError
+		if asExitError(instErr, &exitErr) {
+			r.exitCode = int(exitErr.ExitCode())
+			return
+		}
+		r.err = instErr
+	}
+}
+
+func (r *wazeroRuntime) streamLines(rd io.Reader) {
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		r.console <- scanner.Text()
+	}
+}
+
+func (r *wazeroRuntime) WaitDone(ctx context.Context) (int, error) {
+	select {
+	case <-r.done:
+		return r.exitCode, r.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (r *wazeroRuntime) Console() <-chan string {
+	return r.console
+}
+
+// asExitError reports whether err is itself a *sys.ExitError and, if so, stores it in
+// *target. It is a plain type assertion: unlike errors.As, it does not unwrap err.
+func asExitError(err error, target **sys.ExitError) bool {
+	exitErr, ok := err.(*sys.ExitError)
+	if !ok {
+		return false
+	}
+	*target = exitErr
+	return true
+}